@@ -16,6 +16,7 @@ package cmd
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -26,8 +27,19 @@ import (
 	"istio.io/istio/istioctl/pkg/writer/pilot"
 )
 
+// watchInterval is the default polling period used by `proxy-status --watch`.
+const watchInterval = 5 * time.Second
+
 func statusCommand() *cobra.Command {
-	var opts clioptions.ControlPlaneOptions
+	var (
+		opts             clioptions.ControlPlaneOptions
+		versions         bool
+		outOfDateOnly    bool
+		minorBehindLimit uint
+		output           string
+		watch            bool
+		interval         time.Duration
+	)
 
 	statusCmd := &cobra.Command{
 		Use:   "proxy-status [<pod-name[.namespace]>]",
@@ -41,6 +53,12 @@ Retrieves last sent and last acknowledged xDS sync from Pilot to each Envoy in t
 
 # Retrieve sync diff for a single Envoy and Pilot
 	istioctl proxy-status istio-egressgateway-59585c5b9c-ndc59.istio-system
+
+# Retrieve control plane/proxy version skew across the mesh
+	istioctl proxy-status --versions
+
+# Watch sync status live during a rollout
+	istioctl proxy-status --watch
 `,
 		Aliases: []string{"ps"},
 		RunE: func(c *cobra.Command, args []string) error {
@@ -67,15 +85,55 @@ Retrieves last sent and last acknowledged xDS sync from Pilot to each Envoy in t
 				}
 				return c.Diff()
 			}
-			statuses, err := kubeClient.AllPilotsDiscoveryDo(istioNamespace, "/debug/syncz")
-			if err != nil {
+
+			sw := pilot.StatusWriter{Writer: c.OutOrStdout()}
+			printOnce := func(prev map[string]*pilot.SyncStatus) (map[string]*pilot.SyncStatus, error) {
+				statuses, err := kubeClient.AllPilotsDiscoveryDo(istioNamespace, "/debug/syncz")
+				if err != nil {
+					return nil, err
+				}
+				if versions {
+					return nil, sw.PrintVersions(statuses, pilot.VersionOptions{
+						OutOfDateOnly:    outOfDateOnly,
+						MinorBehindLimit: minorBehindLimit,
+						OutputFormat:     output,
+					})
+				}
+				return sw.PrintStatus(statuses, pilot.StatusOptions{
+					OutputFormat: output,
+					Previous:     prev,
+				})
+			}
+
+			if !watch {
+				_, err := printOnce(nil)
 				return err
 			}
-			sw := pilot.StatusWriter{Writer: c.OutOrStdout()}
-			return sw.PrintAll(statuses)
+
+			var prev map[string]*pilot.SyncStatus
+			for {
+				cur, err := printOnce(prev)
+				if err != nil {
+					return err
+				}
+				prev = cur
+				time.Sleep(interval)
+			}
 		},
 	}
 
+	statusCmd.PersistentFlags().BoolVar(&versions, "versions", false,
+		"Show control plane/proxy version skew grouped by proxy version, instead of sync state")
+	statusCmd.PersistentFlags().BoolVar(&outOfDateOnly, "out-of-date-only", false,
+		"When used with --versions, only show proxies more than --minor-behind-limit minor versions behind the control plane")
+	statusCmd.PersistentFlags().UintVar(&minorBehindLimit, "minor-behind-limit", 1,
+		"When used with --versions, the number of minor versions behind the control plane before a proxy is considered out of date")
+	statusCmd.PersistentFlags().StringVarP(&output, "output", "o", "table",
+		"Output format: one of table, wide, json, yaml")
+	statusCmd.PersistentFlags().BoolVarP(&watch, "watch", "w", false,
+		"Poll sync status at --interval and re-render, highlighting proxies whose xDS state changed since the previous poll")
+	statusCmd.PersistentFlags().DurationVar(&interval, "interval", watchInterval,
+		"Polling interval to use with --watch")
 	opts.AttachControlPlaneFlags(statusCmd)
 
 	return statusCmd
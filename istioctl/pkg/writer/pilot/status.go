@@ -0,0 +1,340 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pilot contains writers for printing Pilot debug information retrieved over /debug
+// endpoints, such as proxy sync status.
+package pilot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ghodss/yaml"
+)
+
+// SyncStatus is the non-proto based status update for the proxy sync state, reported by Pilot's
+// /debug/syncz endpoint.
+type SyncStatus struct {
+	ClusterID     string `json:"cluster_id,omitempty"`
+	ProxyID       string `json:"proxy,omitempty"`
+	ProxyVersion  string `json:"proxy_version,omitempty"`
+	IstioVersion  string `json:"istio_version,omitempty"`
+	ClusterSent   string `json:"cluster_sent,omitempty"`
+	ClusterAcked  string `json:"cluster_acked,omitempty"`
+	ListenerSent  string `json:"listener_sent,omitempty"`
+	ListenerAcked string `json:"listener_acked,omitempty"`
+	RouteSent     string `json:"route_sent,omitempty"`
+	RouteAcked    string `json:"route_acked,omitempty"`
+	EndpointSent  string `json:"endpoint_sent,omitempty"`
+	EndpointAcked string `json:"endpoint_acked,omitempty"`
+
+	// LastFullSync is the RFC3339 timestamp of the last xDS push Pilot received an ACK for from
+	// this proxy. Empty if the proxy has never fully synced.
+	LastFullSync string `json:"last_full_sync,omitempty"`
+}
+
+// StatusWriter enables printing of sync status using multiple outputs.
+type StatusWriter struct {
+	Writer io.Writer
+}
+
+// PrintAll takes a list of Pilot /debug/syncz responses (one per discovered Pilot instance) and
+// prints a single table de-duplicated by proxy ID.
+func (s *StatusWriter) PrintAll(statuses map[string][]byte) error {
+	_, err := s.PrintStatus(statuses, StatusOptions{})
+	return err
+}
+
+// StatusOptions controls how PrintStatus renders sync status.
+type StatusOptions struct {
+	// OutputFormat is one of "table", "wide", "json", or "yaml". Defaults to "table" when empty.
+	OutputFormat string
+
+	// Previous is the result of the prior call to PrintStatus, keyed by proxy ID. When set,
+	// proxies whose xDS state transitioned since Previous are highlighted. Ignored for "json" and
+	// "yaml" output.
+	Previous map[string]*SyncStatus
+}
+
+// statusEntry is a single proxy's rendered sync status, used for "json"/"yaml" output and to back
+// the table renderers.
+type statusEntry struct {
+	ProxyID       string `json:"proxyID"`
+	ClusterID     string `json:"clusterID,omitempty"`
+	ProxyVersion  string `json:"proxyVersion,omitempty"`
+	CDS           string `json:"cds"`
+	LDS           string `json:"lds"`
+	EDS           string `json:"eds"`
+	RDS           string `json:"rds"`
+	PilotInstance string `json:"pilotInstance,omitempty"`
+	LastAckAge    string `json:"lastAckAge,omitempty"`
+}
+
+// PrintStatus prints a single sync-state table de-duplicated by proxy ID, and returns the
+// snapshot it printed so the caller can pass it back in as StatusOptions.Previous on the next
+// call (used by `proxy-status --watch`).
+func (s *StatusWriter) PrintStatus(statuses map[string][]byte, opts StatusOptions) (map[string]*SyncStatus, error) {
+	_, fullStatus, err := s.setupStatusPrint(statuses)
+	if err != nil {
+		return nil, err
+	}
+
+	cur := make(map[string]*SyncStatus, len(fullStatus))
+	entries := make([]statusEntry, 0, len(fullStatus))
+	for _, status := range fullStatus {
+		if status.ProxyID == "" {
+			continue
+		}
+		cur[status.ProxyID] = status.SyncStatus
+
+		cds := xdsStatus(status.ClusterSent, status.ClusterAcked)
+		lds := xdsStatus(status.ListenerSent, status.ListenerAcked)
+		eds := xdsStatus(status.EndpointSent, status.EndpointAcked)
+		rds := xdsStatus(status.RouteSent, status.RouteAcked)
+
+		structured := opts.OutputFormat == "json" || opts.OutputFormat == "yaml"
+		if prev, ok := opts.Previous[status.ProxyID]; ok && !structured {
+			cds = markIfChanged(cds, xdsStatus(prev.ClusterSent, prev.ClusterAcked))
+			lds = markIfChanged(lds, xdsStatus(prev.ListenerSent, prev.ListenerAcked))
+			eds = markIfChanged(eds, xdsStatus(prev.EndpointSent, prev.EndpointAcked))
+			rds = markIfChanged(rds, xdsStatus(prev.RouteSent, prev.RouteAcked))
+		}
+
+		entries = append(entries, statusEntry{
+			ProxyID:       status.ProxyID,
+			ClusterID:     status.ClusterID,
+			ProxyVersion:  status.ProxyVersion,
+			CDS:           cds,
+			LDS:           lds,
+			EDS:           eds,
+			RDS:           rds,
+			PilotInstance: status.PilotInstance,
+			LastAckAge:    ackAge(status.LastFullSync),
+		})
+	}
+
+	switch opts.OutputFormat {
+	case "json":
+		out, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fmt.Fprintln(s.Writer, string(out)); err != nil {
+			return nil, err
+		}
+	case "yaml":
+		out, err := yaml.Marshal(entries)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fmt.Fprintln(s.Writer, string(out)); err != nil {
+			return nil, err
+		}
+	case "", "table", "wide":
+		wide := opts.OutputFormat == "wide"
+		w := new(tabwriter.Writer).Init(s.Writer, 0, 8, 5, ' ', 0)
+		header := "NAME\tCDS\tLDS\tEDS\tRDS\tCLUSTER\tVERSION"
+		if wide {
+			header = "NAME\tCDS\tLDS\tEDS\tRDS\tCLUSTER\tVERSION\tPILOT INSTANCE\tLAST ACK AGE"
+		}
+		_, _ = fmt.Fprintln(w, header)
+		for _, e := range entries {
+			if wide {
+				_, _ = fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+					e.ProxyID, e.CDS, e.LDS, e.EDS, e.RDS, e.ClusterID, e.ProxyVersion, e.PilotInstance, e.LastAckAge)
+			} else {
+				_, _ = fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+					e.ProxyID, e.CDS, e.LDS, e.EDS, e.RDS, e.ClusterID, e.ProxyVersion)
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown output format %q: must be one of table, wide, json, yaml", opts.OutputFormat)
+	}
+
+	return cur, nil
+}
+
+// ackAge renders how long ago Pilot recorded lastFullSync (an RFC3339 timestamp) as a duration
+// string, or "" if lastFullSync is empty or cannot be parsed.
+func ackAge(lastFullSync string) string {
+	if lastFullSync == "" {
+		return ""
+	}
+	t, err := time.Parse(time.RFC3339, lastFullSync)
+	if err != nil {
+		return ""
+	}
+	return time.Since(t).Round(time.Second).String()
+}
+
+// markIfChanged annotates cur with a "(changed)" suffix when it differs from prev, so that
+// `--watch` output calls out SENT->ACKED and ACKED->STALE transitions since the last poll.
+func markIfChanged(cur, prev string) string {
+	if cur == prev {
+		return cur
+	}
+	return cur + " (changed)"
+}
+
+// pilotStatus pairs a SyncStatus with the pilot instance that reported it, so that wide output
+// can show which control plane instance owns a given proxy.
+type pilotStatus struct {
+	*SyncStatus
+	PilotInstance string
+}
+
+func (s *StatusWriter) setupStatusPrint(statuses map[string][]byte) (*tabwriter.Writer, []*pilotStatus, error) {
+	index := map[string]*pilotStatus{}
+	for instance, status := range statuses {
+		var ss []*SyncStatus
+		if err := json.Unmarshal(status, &ss); err != nil {
+			return nil, nil, fmt.Errorf("unmarshal: %v", err)
+		}
+		for _, st := range ss {
+			index[st.ProxyID] = &pilotStatus{SyncStatus: st, PilotInstance: instance}
+		}
+	}
+
+	fullStatus := make([]*pilotStatus, 0, len(index))
+	for _, st := range index {
+		fullStatus = append(fullStatus, st)
+	}
+	sort.Slice(fullStatus, func(i, j int) bool {
+		return fullStatus[i].ProxyID < fullStatus[j].ProxyID
+	})
+
+	w := new(tabwriter.Writer).Init(s.Writer, 0, 8, 5, ' ', 0)
+	return w, fullStatus, nil
+}
+
+// VersionOptions controls how PrintVersions renders control-plane/proxy version skew.
+type VersionOptions struct {
+	// OutOfDateOnly restricts output to proxies more than MinorBehindLimit minor versions
+	// behind the newest control plane version observed.
+	OutOfDateOnly bool
+
+	// MinorBehindLimit is the number of minor versions behind the control plane a proxy may be
+	// before it is considered out of date. Defaults to 1 when zero.
+	MinorBehindLimit uint
+
+	// OutputFormat is one of "table", "json", or "yaml". Defaults to "table" when empty.
+	OutputFormat string
+}
+
+// versionGroup summarizes every proxy running a given proxy version.
+type versionGroup struct {
+	ProxyVersion string   `json:"proxyVersion"`
+	Count        int      `json:"count"`
+	MinorsBehind int      `json:"minorsBehind"`
+	Proxies      []string `json:"proxies"`
+}
+
+// PrintVersions groups proxies by their reported proxy version, compares each group against the
+// newest control plane (Pilot) version observed in statuses, and prints the resulting skew.
+func (s *StatusWriter) PrintVersions(statuses map[string][]byte, opts VersionOptions) error {
+	if opts.MinorBehindLimit == 0 {
+		opts.MinorBehindLimit = 1
+	}
+
+	_, fullStatus, err := s.setupStatusPrint(statuses)
+	if err != nil {
+		return err
+	}
+
+	controlPlaneVersion := ""
+	byVersion := map[string]*versionGroup{}
+	for _, st := range fullStatus {
+		if st.ProxyID == "" {
+			continue
+		}
+		if minor(st.IstioVersion) > minor(controlPlaneVersion) {
+			controlPlaneVersion = st.IstioVersion
+		}
+		g, ok := byVersion[st.ProxyVersion]
+		if !ok {
+			g = &versionGroup{ProxyVersion: st.ProxyVersion}
+			byVersion[st.ProxyVersion] = g
+		}
+		g.Count++
+		g.Proxies = append(g.Proxies, st.ProxyID)
+	}
+
+	groups := make([]*versionGroup, 0, len(byVersion))
+	for _, g := range byVersion {
+		g.MinorsBehind = minor(controlPlaneVersion) - minor(g.ProxyVersion)
+		if opts.OutOfDateOnly && g.MinorsBehind <= int(opts.MinorBehindLimit) {
+			continue
+		}
+		sort.Strings(g.Proxies)
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].ProxyVersion < groups[j].ProxyVersion })
+
+	switch opts.OutputFormat {
+	case "json":
+		out, err := json.MarshalIndent(groups, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(s.Writer, string(out))
+		return err
+	case "yaml":
+		out, err := yaml.Marshal(groups)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(s.Writer, string(out))
+		return err
+	default:
+		w := new(tabwriter.Writer).Init(s.Writer, 0, 8, 5, ' ', 0)
+		_, _ = fmt.Fprintln(w, "PROXY VERSION\tCOUNT\tMINORS BEHIND\tISTIOD VERSION")
+		for _, g := range groups {
+			_, _ = fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", g.ProxyVersion, g.Count, g.MinorsBehind, controlPlaneVersion)
+		}
+		return w.Flush()
+	}
+}
+
+// minor extracts the major and minor version (e.g. "1", "9" from "1.9.2") from an Istio version
+// string and combines them into a single comparable ordinal, so that versions can be ordered
+// by (major, minor) rather than by minor alone. Returns -1 if the version cannot be parsed.
+func minor(v string) int {
+	parts := strings.Split(strings.TrimPrefix(v, "v"), ".")
+	if len(parts) < 2 {
+		return -1
+	}
+	var maj, min int
+	if _, err := fmt.Sscanf(parts[0]+"."+parts[1], "%d.%d", &maj, &min); err != nil {
+		return -1
+	}
+	return maj*1000 + min
+}
+
+func xdsStatus(sent, acked string) string {
+	if sent == "" {
+		return "NOT SENT"
+	}
+	if sent == acked {
+		return "SYNCED"
+	}
+	return "STALE (Last Acked: " + acked + ", Last Sent: " + sent + ")"
+}
@@ -0,0 +1,253 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pilot
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMinor(t *testing.T) {
+	cases := []struct {
+		version string
+		want    int
+	}{
+		{"1.9.2", 1009},
+		{"v1.9.2", 1009},
+		{"1.12.0", 1012},
+		{"2.1.0", 2001},
+		{"1", -1},
+		{"", -1},
+		{"garbage", -1},
+	}
+
+	for _, c := range cases {
+		if got := minor(c.version); got != c.want {
+			t.Errorf("minor(%q) = %d, want %d", c.version, got, c.want)
+		}
+	}
+
+	// A major version bump must compare as newer than any minor version within the prior major,
+	// which a minor-only comparison would get backwards (e.g. 2.1.0 vs 1.9.0).
+	if !(minor("2.1.0") > minor("1.9.0")) {
+		t.Errorf("expected minor(2.1.0) > minor(1.9.0), got %d vs %d", minor("2.1.0"), minor("1.9.0"))
+	}
+}
+
+func TestPrintVersionsMajorVersionJump(t *testing.T) {
+	statuses := map[string][]byte{
+		"istiod-1": mustMarshal(t, []*SyncStatus{
+			{ProxyID: "old-proxy", ProxyVersion: "1.9.0", IstioVersion: "1.9.0"},
+			{ProxyID: "new-proxy", ProxyVersion: "2.1.0", IstioVersion: "2.1.0"},
+		}),
+	}
+
+	var buf bytes.Buffer
+	sw := &StatusWriter{Writer: &buf}
+	if err := sw.PrintVersions(statuses, VersionOptions{OutputFormat: "json"}); err != nil {
+		t.Fatalf("PrintVersions failed: %v", err)
+	}
+
+	var groups []versionGroup
+	if err := json.Unmarshal(buf.Bytes(), &groups); err != nil {
+		t.Fatalf("failed to unmarshal output: %v, output: %s", err, buf.String())
+	}
+
+	byVersion := map[string]versionGroup{}
+	for _, g := range groups {
+		byVersion[g.ProxyVersion] = g
+	}
+
+	if g := byVersion["2.1.0"]; g.MinorsBehind != 0 {
+		t.Errorf("expected the newest (control plane) version to be 0 minors behind, got %d", g.MinorsBehind)
+	}
+	if g := byVersion["1.9.0"]; g.MinorsBehind <= 0 {
+		t.Errorf("expected 1.9.0 to be behind the 2.1.0 control plane, got %d", g.MinorsBehind)
+	}
+}
+
+func TestPrintVersionsOutOfDateOnly(t *testing.T) {
+	statuses := map[string][]byte{
+		"istiod-1": mustMarshal(t, []*SyncStatus{
+			{ProxyID: "current-proxy", ProxyVersion: "1.9.0", IstioVersion: "1.9.0"},
+			{ProxyID: "stale-proxy", ProxyVersion: "1.7.0", IstioVersion: "1.7.0"},
+		}),
+	}
+
+	var buf bytes.Buffer
+	sw := &StatusWriter{Writer: &buf}
+	if err := sw.PrintVersions(statuses, VersionOptions{OutOfDateOnly: true, MinorBehindLimit: 1, OutputFormat: "json"}); err != nil {
+		t.Fatalf("PrintVersions failed: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "1.9.0") {
+		t.Errorf("expected up-to-date proxy version to be filtered out, got: %s", out)
+	}
+	if !strings.Contains(out, "1.7.0") {
+		t.Errorf("expected stale proxy version to be present, got: %s", out)
+	}
+}
+
+func TestMarkIfChanged(t *testing.T) {
+	if got := markIfChanged("SYNCED", "SYNCED"); got != "SYNCED" {
+		t.Errorf("expected unchanged status to be left as-is, got %q", got)
+	}
+	if got := markIfChanged("SYNCED", "STALE"); got != "SYNCED (changed)" {
+		t.Errorf("expected changed status to be annotated, got %q", got)
+	}
+}
+
+func TestPrintStatusWatchHighlightsChanges(t *testing.T) {
+	statuses := map[string][]byte{
+		"istiod-1": mustMarshal(t, []*SyncStatus{
+			{ProxyID: "proxy-1", ProxyVersion: "1.9.0", ClusterSent: "1", ClusterAcked: "1"},
+		}),
+	}
+
+	sw := &StatusWriter{Writer: &bytes.Buffer{}}
+	prev, err := sw.PrintStatus(statuses, StatusOptions{})
+	if err != nil {
+		t.Fatalf("PrintStatus failed: %v", err)
+	}
+
+	statuses["istiod-1"] = mustMarshal(t, []*SyncStatus{
+		{ProxyID: "proxy-1", ProxyVersion: "1.9.0", ClusterSent: "2", ClusterAcked: "1"},
+	})
+
+	var buf bytes.Buffer
+	sw.Writer = &buf
+	if _, err := sw.PrintStatus(statuses, StatusOptions{Previous: prev}); err != nil {
+		t.Fatalf("PrintStatus failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "(changed)") {
+		t.Errorf("expected xDS state change since the previous poll to be highlighted, got: %s", buf.String())
+	}
+}
+
+func TestPrintStatusWideIncludesPilotInstance(t *testing.T) {
+	statuses := map[string][]byte{
+		"istiod-1": mustMarshal(t, []*SyncStatus{
+			{ProxyID: "proxy-1", ProxyVersion: "1.9.0"},
+		}),
+	}
+
+	var buf bytes.Buffer
+	sw := &StatusWriter{Writer: &buf}
+	if _, err := sw.PrintStatus(statuses, StatusOptions{OutputFormat: "wide"}); err != nil {
+		t.Fatalf("PrintStatus failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "istiod-1") {
+		t.Errorf("expected wide output to include the reporting Pilot instance, got: %s", buf.String())
+	}
+}
+
+func TestPrintStatusJSON(t *testing.T) {
+	statuses := map[string][]byte{
+		"istiod-1": mustMarshal(t, []*SyncStatus{
+			{ProxyID: "proxy-1", ProxyVersion: "1.9.0", ClusterSent: "1", ClusterAcked: "1"},
+		}),
+	}
+
+	var buf bytes.Buffer
+	sw := &StatusWriter{Writer: &buf}
+	if _, err := sw.PrintStatus(statuses, StatusOptions{OutputFormat: "json"}); err != nil {
+		t.Fatalf("PrintStatus failed: %v", err)
+	}
+
+	var entries []statusEntry
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("failed to unmarshal JSON output: %v, output: %s", err, buf.String())
+	}
+	if len(entries) != 1 || entries[0].ProxyID != "proxy-1" {
+		t.Errorf("expected a single entry for proxy-1, got %v", entries)
+	}
+}
+
+func TestPrintStatusYAML(t *testing.T) {
+	statuses := map[string][]byte{
+		"istiod-1": mustMarshal(t, []*SyncStatus{
+			{ProxyID: "proxy-1", ProxyVersion: "1.9.0"},
+		}),
+	}
+
+	var buf bytes.Buffer
+	sw := &StatusWriter{Writer: &buf}
+	if _, err := sw.PrintStatus(statuses, StatusOptions{OutputFormat: "yaml"}); err != nil {
+		t.Fatalf("PrintStatus failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "proxy-1") {
+		t.Errorf("expected YAML output to contain proxy-1, got: %s", buf.String())
+	}
+}
+
+func TestPrintStatusUnknownFormat(t *testing.T) {
+	statuses := map[string][]byte{
+		"istiod-1": mustMarshal(t, []*SyncStatus{{ProxyID: "proxy-1"}}),
+	}
+
+	sw := &StatusWriter{Writer: &bytes.Buffer{}}
+	if _, err := sw.PrintStatus(statuses, StatusOptions{OutputFormat: "csv"}); err == nil {
+		t.Error("expected an error for an unknown output format, got nil")
+	}
+}
+
+func TestPrintStatusWideIncludesLastAckAge(t *testing.T) {
+	statuses := map[string][]byte{
+		"istiod-1": mustMarshal(t, []*SyncStatus{
+			{ProxyID: "proxy-1", ProxyVersion: "1.9.0", LastFullSync: "2020-01-01T00:00:00Z"},
+			{ProxyID: "proxy-2", ProxyVersion: "1.9.0"},
+		}),
+	}
+
+	var buf bytes.Buffer
+	sw := &StatusWriter{Writer: &buf}
+	if _, err := sw.PrintStatus(statuses, StatusOptions{OutputFormat: "wide"}); err != nil {
+		t.Fatalf("PrintStatus failed: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "LAST ACK AGE") {
+		t.Errorf("expected wide output to have a LAST ACK AGE column, got: %s", out)
+	}
+	if !strings.Contains(out, "proxy-1") {
+		t.Fatalf("expected proxy-1 to be present, got: %s", out)
+	}
+}
+
+func TestAckAge(t *testing.T) {
+	if got := ackAge(""); got != "" {
+		t.Errorf("ackAge(\"\") = %q, want \"\"", got)
+	}
+	if got := ackAge("not-a-timestamp"); got != "" {
+		t.Errorf("ackAge(garbage) = %q, want \"\"", got)
+	}
+	if got := ackAge("2020-01-01T00:00:00Z"); got == "" {
+		t.Error("expected a non-empty age for a valid past timestamp")
+	}
+}
+
+func mustMarshal(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal test fixture: %v", err)
+	}
+	return b
+}
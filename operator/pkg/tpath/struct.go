@@ -0,0 +1,492 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tpath resolves a dot-separated path against an unstructured tree of
+// map[string]interface{}/[]interface{} nodes (the shape produced by unmarshaling YAML/JSON into
+// interface{}), as used for merging and diffing IstioOperator overlays.
+package tpath
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"istio.io/istio/operator/pkg/util"
+)
+
+type stepKind int
+
+const (
+	stepKey stepKind = iota
+	stepIndex
+	stepWildcard
+	stepPredicate
+)
+
+// step is one segment of a parsed struct path.
+type step struct {
+	kind stepKind
+
+	// key is used by stepKey.
+	key string
+	// index is used by stepIndex. May be negative to index from the end, as in append(-1).
+	index int
+	// predField and predValue are used by stepPredicate: the path matches slice elements whose
+	// predField equals predValue.
+	predField string
+	predValue string
+}
+
+func (s step) String() string {
+	switch s.kind {
+	case stepKey:
+		return s.key
+	case stepIndex:
+		return strconv.Itoa(s.index)
+	case stepWildcard:
+		return "*"
+	case stepPredicate:
+		return fmt.Sprintf("[?(@.%s==%s)]", s.predField, s.predValue)
+	default:
+		return "?"
+	}
+}
+
+// GetFromStructPath returns the value at path from the given node, or false if the path does not
+// exist. path is a dot-separated sequence of map keys and slice indices (e.g. "a.b.2.c"), and may
+// additionally use:
+//   - "*" as a wildcard segment matching every map key or slice index, returning []interface{}
+//   - "[<n>]" bracket indexing as an alternative to ".<n>"
+//   - a predicate segment "[?(@.field=='value')]" / "[?(@.field==123)]" that filters slice
+//     elements by a scalar child field, returning the matching subtree if there is a single match
+//     or a []interface{} if there are several
+func GetFromStructPath(node interface{}, path string) (interface{}, bool, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, false, err
+	}
+	return getFromStructPath(node, steps)
+}
+
+func getFromStructPath(node interface{}, steps []step) (interface{}, bool, error) {
+	if len(steps) == 0 {
+		return node, !util.IsValueNil(node), nil
+	}
+
+	cur, rest := steps[0], steps[1:]
+
+	switch cur.kind {
+	case stepKey:
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("getFromStructPath path %s, unsupported type %T", cur, node)
+		}
+		child, ok := m[cur.key]
+		if !ok {
+			return nil, false, nil
+		}
+		return getFromStructPath(child, rest)
+
+	case stepIndex:
+		s, ok := node.([]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("getFromStructPath path %s, unsupported type %T", cur, node)
+		}
+		idx := cur.index
+		if idx < 0 {
+			idx += len(s)
+		}
+		if idx < 0 || idx >= len(s) {
+			return nil, false, nil
+		}
+		return getFromStructPath(s[idx], rest)
+
+	case stepWildcard:
+		children, err := wildcardChildren(node, cur)
+		if err != nil {
+			return nil, false, err
+		}
+		var out []interface{}
+		for _, child := range children {
+			v, found, err := getFromStructPath(child, rest)
+			if err != nil {
+				return nil, false, err
+			}
+			if found {
+				out = append(out, v)
+			}
+		}
+		return out, len(out) > 0, nil
+
+	case stepPredicate:
+		matches, err := predicateMatches(node, cur)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(matches) == 0 {
+			return nil, false, nil
+		}
+		if len(matches) == 1 {
+			return getFromStructPath(matches[0], rest)
+		}
+		var out []interface{}
+		for _, match := range matches {
+			v, found, err := getFromStructPath(match, rest)
+			if err != nil {
+				return nil, false, err
+			}
+			if found {
+				out = append(out, v)
+			}
+		}
+		return out, len(out) > 0, nil
+
+	default:
+		return nil, false, fmt.Errorf("getFromStructPath path %s, unknown step kind", cur)
+	}
+}
+
+// SetFromStructPath sets the value at path in node to value, returning false if path does not
+// already exist, except that a map key named by the final segment is added if it is missing
+// (and true is returned). It supports the same grammar as GetFromStructPath; a wildcard or predicate in
+// the final segment sets every matching entry.
+func SetFromStructPath(node interface{}, path string, value interface{}) (bool, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return false, err
+	}
+	if len(steps) == 0 {
+		return false, nil
+	}
+	return setFromStructPath(node, steps, value, false)
+}
+
+// setFromStructPath sets the value at the given steps to value, or - when del is true - removes
+// map keys and clears slice entries to nil instead of setting them to value (value is ignored
+// when del is true).
+func setFromStructPath(node interface{}, steps []step, value interface{}, del bool) (bool, error) {
+	cur, rest := steps[0], steps[1:]
+
+	if len(rest) == 0 {
+		switch cur.kind {
+		case stepKey:
+			m, ok := node.(map[string]interface{})
+			if !ok {
+				return false, fmt.Errorf("setFromStructPath path %s, unsupported type %T", cur, node)
+			}
+			if del {
+				if _, ok := m[cur.key]; !ok {
+					return false, nil
+				}
+				delete(m, cur.key)
+				return true, nil
+			}
+			m[cur.key] = value
+			return true, nil
+		case stepIndex:
+			s, ok := node.([]interface{})
+			if !ok {
+				return false, fmt.Errorf("setFromStructPath path %s, unsupported type %T", cur, node)
+			}
+			idx := cur.index
+			if idx < 0 {
+				idx += len(s)
+			}
+			if idx < 0 || idx >= len(s) {
+				return false, nil
+			}
+			if del {
+				s[idx] = nil
+				return true, nil
+			}
+			s[idx] = value
+			return true, nil
+		case stepWildcard:
+			children, err := wildcardChildren(node, cur)
+			if err != nil {
+				return false, err
+			}
+			switch n := node.(type) {
+			case []interface{}:
+				for i := range n {
+					if del {
+						n[i] = nil
+						continue
+					}
+					n[i] = value
+				}
+			case map[string]interface{}:
+				if del {
+					for k := range n {
+						delete(n, k)
+					}
+				} else {
+					for k := range n {
+						n[k] = value
+					}
+				}
+			}
+			return len(children) > 0, nil
+		case stepPredicate:
+			s, ok := node.([]interface{})
+			if !ok {
+				return false, fmt.Errorf("setFromStructPath path %s, unsupported type %T", cur, node)
+			}
+			matched := false
+			for i, item := range s {
+				m, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if fmt.Sprint(m[cur.predField]) == cur.predValue {
+					if del {
+						s[i] = nil
+					} else {
+						s[i] = value
+					}
+					matched = true
+				}
+			}
+			return matched, nil
+		}
+	}
+
+	switch cur.kind {
+	case stepKey:
+		m, ok := node.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("setFromStructPath path %s, unsupported type %T", cur, node)
+		}
+		child, ok := m[cur.key]
+		if !ok {
+			return false, nil
+		}
+		return setFromStructPath(child, rest, value, del)
+
+	case stepIndex:
+		s, ok := node.([]interface{})
+		if !ok {
+			return false, fmt.Errorf("setFromStructPath path %s, unsupported type %T", cur, node)
+		}
+		idx := cur.index
+		if idx < 0 {
+			idx += len(s)
+		}
+		if idx < 0 || idx >= len(s) {
+			return false, nil
+		}
+		return setFromStructPath(s[idx], rest, value, del)
+
+	case stepWildcard:
+		children, err := wildcardChildren(node, cur)
+		if err != nil {
+			return false, err
+		}
+		any := false
+		for _, child := range children {
+			ok, err := setFromStructPath(child, rest, value, del)
+			if err != nil {
+				return false, err
+			}
+			any = any || ok
+		}
+		return any, nil
+
+	case stepPredicate:
+		matches, err := predicateMatches(node, cur)
+		if err != nil {
+			return false, err
+		}
+		any := false
+		for _, match := range matches {
+			ok, err := setFromStructPath(match, rest, value, del)
+			if err != nil {
+				return false, err
+			}
+			any = any || ok
+		}
+		return any, nil
+	}
+
+	return false, fmt.Errorf("setFromStructPath path %s, unknown step kind", cur)
+}
+
+// DeleteFromStructPath deletes the value at path from node, returning false if path does not
+// exist. Deleting a map key removes it; deleting a slice element (by index or predicate) clears
+// it to nil in place rather than shortening the slice, since doing the latter would require
+// rewriting the slice's parent container as well.
+func DeleteFromStructPath(node interface{}, path string) (bool, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return false, err
+	}
+	if len(steps) == 0 {
+		return false, nil
+	}
+	return setFromStructPath(node, steps, nil, true)
+}
+
+func wildcardChildren(node interface{}, cur step) ([]interface{}, error) {
+	switch n := node.(type) {
+	case []interface{}:
+		return n, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(n))
+		for k := range n {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		out := make([]interface{}, 0, len(keys))
+		for _, k := range keys {
+			out = append(out, n[k])
+		}
+		return out, nil
+	default:
+		return nil, fmt.Errorf("getFromStructPath path %s, unsupported type %T", cur, node)
+	}
+}
+
+func predicateMatches(node interface{}, cur step) ([]interface{}, error) {
+	s, ok := node.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("getFromStructPath path %s, unsupported type %T", cur, node)
+	}
+	var out []interface{}
+	for _, item := range s {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		v, ok := m[cur.predField]
+		if !ok {
+			continue
+		}
+		if fmt.Sprint(v) == cur.predValue {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+// parsePath tokenizes a struct path into steps. Dots separate top-level segments except where
+// they appear inside a "[...]" group; each segment may be a bare key, a bare integer (for
+// backwards-compatible slice indexing), a "*" wildcard, or a key/wildcard/bare-nothing followed
+// by one or more "[...]" groups (bracket index, "[*]" wildcard, or "[?(@.field==value)]"
+// predicate).
+func parsePath(path string) ([]step, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	var steps []step
+	for _, token := range splitTopLevel(path) {
+		tokenSteps, err := parseToken(token)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, tokenSteps...)
+	}
+	return steps, nil
+}
+
+func splitTopLevel(path string) []string {
+	var tokens []string
+	depth := 0
+	start := 0
+	for i, r := range path {
+		switch r {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case '.':
+			if depth == 0 {
+				tokens = append(tokens, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+	tokens = append(tokens, path[start:])
+	return tokens
+}
+
+func parseToken(token string) ([]step, error) {
+	if token == "*" {
+		return []step{{kind: stepWildcard}}, nil
+	}
+	if idx, err := strconv.Atoi(token); err == nil {
+		return []step{{kind: stepIndex, index: idx}}, nil
+	}
+
+	var steps []step
+	name := token
+	if i := strings.IndexByte(token, '['); i >= 0 {
+		name = token[:i]
+		rest := token[i:]
+		for len(rest) > 0 {
+			if rest[0] != '[' {
+				return nil, fmt.Errorf("invalid path token %q", token)
+			}
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated bracket in path token %q", token)
+			}
+			content := rest[1:end]
+			s, err := parseBracket(content)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path token %q: %v", token, err)
+			}
+			if name == "*" {
+				steps = append(steps, step{kind: stepWildcard})
+			} else if name != "" {
+				steps = append(steps, step{kind: stepKey, key: name})
+			}
+			name = ""
+			steps = append(steps, s)
+			rest = rest[end+1:]
+		}
+	}
+
+	if name != "" {
+		steps = append([]step{{kind: stepKey, key: name}}, steps...)
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("invalid empty path token %q", token)
+	}
+	return steps, nil
+}
+
+func parseBracket(content string) (step, error) {
+	if content == "*" {
+		return step{kind: stepWildcard}, nil
+	}
+	if strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")") {
+		expr := strings.TrimSuffix(strings.TrimPrefix(content, "?("), ")")
+		const prefix = "@."
+		eq := strings.Index(expr, "==")
+		if !strings.HasPrefix(expr, prefix) || eq < 0 {
+			return step{}, fmt.Errorf("invalid predicate %q, want [?(@.field=='value')]", content)
+		}
+		field := expr[len(prefix):eq]
+		value := strings.TrimSpace(expr[eq+2:])
+		value = strings.Trim(value, `'"`)
+		return step{kind: stepPredicate, predField: field, predValue: value}, nil
+	}
+	idx, err := strconv.Atoi(content)
+	if err != nil {
+		return step{}, fmt.Errorf("invalid bracket index %q", content)
+	}
+	return step{kind: stepIndex, index: idx}, nil
+}
@@ -119,6 +119,55 @@ g:
 			wantFound: false,
 			wantErr:   "getFromStructPath path e, unsupported type string",
 		},
+		{
+			desc: "GetBracketIndex",
+			nodeYAML: `
+g:
+  h:
+  - i: vi
+    j: vj
+`,
+			path: "g.h[0].i",
+			wantYAML: `
+vi
+`,
+			wantFound: true,
+		},
+		{
+			desc: "GetPredicateSingleMatch",
+			nodeYAML: `
+g:
+  h:
+  - i: vi
+    j: vj
+  - i: vi2
+    j: vj2
+`,
+			path: "g.h[?(@.i=='vi2')].j",
+			wantYAML: `
+vj2
+`,
+			wantFound: true,
+		},
+		{
+			desc: "GetWildcardCompose",
+			nodeYAML: `
+g:
+  h:
+  - k:
+      l:
+        m: vm1
+  - k:
+      l:
+        m: vm2
+`,
+			path: "g.h.*.k.l.m",
+			wantYAML: `
+- vm1
+- vm2
+`,
+			wantFound: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -145,3 +194,56 @@ g:
 		})
 	}
 }
+
+func TestSetAndDeleteFromStructPath(t *testing.T) {
+	nodeYAML := `
+g:
+  h:
+  - i: vi
+    j: vj
+  - i: vi2
+    j: vj2
+`
+	rnode := make(map[string]interface{})
+	if err := yaml.Unmarshal([]byte(nodeYAML), &rnode); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := SetFromStructPath(rnode, "g.h[?(@.i=='vi2')].j", "updated"); err != nil || !ok {
+		t.Fatalf("SetFromStructPath: got (%v, %v), want (true, nil)", ok, err)
+	}
+	got, found, err := GetFromStructPath(rnode, "g.h[1].j")
+	if err != nil || !found || got != "updated" {
+		t.Fatalf("GetFromStructPath after set: got (%v, %v, %v), want (\"updated\", true, nil)", got, found, err)
+	}
+
+	if ok, err := DeleteFromStructPath(rnode, "g.h[0].j"); err != nil || !ok {
+		t.Fatalf("DeleteFromStructPath: got (%v, %v), want (true, nil)", ok, err)
+	}
+	if _, found, err := GetFromStructPath(rnode, "g.h[0].j"); err != nil || found {
+		t.Fatalf("GetFromStructPath after delete: got found=%v, err=%v, want found=false", found, err)
+	}
+}
+
+func TestDeleteFromStructPathRemovesMapKey(t *testing.T) {
+	node := map[string]interface{}{"a": "va", "b": "vb"}
+
+	ok, err := DeleteFromStructPath(node, "a")
+	if err != nil || !ok {
+		t.Fatalf("DeleteFromStructPath: got (%v, %v), want (true, nil)", ok, err)
+	}
+
+	if _, present := node["a"]; present {
+		t.Errorf("expected key %q to be removed from the map, got %v", "a", node)
+	}
+	if v, present := node["b"]; !present || v != "vb" {
+		t.Errorf("expected unrelated key %q to be left untouched, got %v", "b", node)
+	}
+}
+
+func errToString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,64 @@
+// Copyright Istio Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package util contains utilities shared across the operator codebase for working with
+// unstructured YAML trees (map[string]interface{} / []interface{}).
+package util
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ghodss/yaml"
+)
+
+// ToYAML returns a YAML string representation of val, or an error string if val cannot be
+// marshaled.
+func ToYAML(val interface{}) string {
+	y, err := yaml.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("# yaml.Marshal error: %s", err)
+	}
+	return string(y)
+}
+
+// YAMLDiff returns an empty string if a and b are semantically equivalent YAML documents, or a
+// human-readable description of how they differ otherwise.
+func YAMLDiff(a, b string) string {
+	var ao, bo interface{}
+	if err := yaml.Unmarshal([]byte(a), &ao); err != nil {
+		return fmt.Sprintf("could not unmarshal a: %s", err)
+	}
+	if err := yaml.Unmarshal([]byte(b), &bo); err != nil {
+		return fmt.Sprintf("could not unmarshal b: %s", err)
+	}
+	if reflect.DeepEqual(ao, bo) {
+		return ""
+	}
+	return fmt.Sprintf("got:\n%s\nwant:\n%s", ToYAML(ao), ToYAML(bo))
+}
+
+// IsValueNil reports whether val is nil, including a typed nil held in an interface.
+func IsValueNil(val interface{}) bool {
+	if val == nil {
+		return true
+	}
+	v := reflect.ValueOf(val)
+	switch v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,152 @@
+//  Copyright Istio Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+// Package docker provides minimal helpers for managing Docker resources needed by the native
+// test Environment.
+package docker
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	dockernetwork "github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// IPAMConfig describes one IPAM pool (subnet/gateway/aux-addresses) for a Docker network. A
+// NetworkConfig with no IPAMConfigs falls back to Docker's default pool allocation.
+type IPAMConfig struct {
+	// Subnet is the IPv4 or IPv6 CIDR for this pool, e.g. "172.28.0.0/16" or "fd00:1::/64".
+	Subnet string
+
+	// Gateway is the IPv4 or IPv6 gateway address for Subnet. Optional.
+	Gateway string
+
+	// AuxAddresses reserves specific addresses within Subnet (name -> address) so they are never
+	// handed out to containers, e.g. for a well-known east-west gateway IP.
+	AuxAddresses map[string]string
+}
+
+// NetworkConfig describes a Docker network to be created for a native test Environment.
+type NetworkConfig struct {
+	// Name of the network.
+	Name string
+
+	// Labels applied to the network.
+	Labels map[string]string
+
+	// Driver is the network driver to use (e.g. "bridge"). Defaults to the Docker daemon's
+	// default driver when empty.
+	Driver string
+
+	// DriverOpts are passed through verbatim to the driver, e.g. "com.docker.network.driver.mtu".
+	DriverOpts map[string]string
+
+	// IPAM configures the network's IPv4 and/or IPv6 address pools. An entry with a Subnet
+	// containing a colon is treated as an IPv6 pool and sets EnableIPv6 on the network.
+	IPAM []IPAMConfig
+
+	// MTU sets "com.docker.network.driver.mtu" in DriverOpts. Ignored if that key is already
+	// set explicitly in DriverOpts.
+	MTU int
+
+	// Internal, when true, restricts the network to container-to-container traffic, with no
+	// outbound or inbound access to/from outside the network.
+	Internal bool
+}
+
+// Network is a handle to a Docker network created for a test Environment.
+type Network struct {
+	client *client.Client
+	id     string
+	name   string
+}
+
+// NewNetwork creates a new Docker network using cfg.
+func NewNetwork(c *client.Client, cfg NetworkConfig) (*Network, error) {
+	driverOpts := driverOptsWithMTU(cfg)
+
+	ipam := &dockernetwork.IPAM{}
+	enableIPv6 := false
+	for _, pool := range cfg.IPAM {
+		ipam.Config = append(ipam.Config, dockernetwork.IPAMConfig{
+			Subnet:     pool.Subnet,
+			Gateway:    pool.Gateway,
+			AuxAddress: pool.AuxAddresses,
+		})
+		if isIPv6CIDR(pool.Subnet) {
+			enableIPv6 = true
+		}
+	}
+
+	resp, err := c.NetworkCreate(context.Background(), cfg.Name, types.NetworkCreate{
+		Driver:     cfg.Driver,
+		Options:    driverOpts,
+		Labels:     cfg.Labels,
+		IPAM:       ipam,
+		Internal:   cfg.Internal,
+		EnableIPv6: enableIPv6,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Network{
+		client: c,
+		id:     resp.ID,
+		name:   cfg.Name,
+	}, nil
+}
+
+// Name returns the name of the network.
+func (n *Network) Name() string {
+	return n.name
+}
+
+// ID returns the Docker-assigned ID of the network.
+func (n *Network) ID() string {
+	return n.id
+}
+
+// Close removes the network.
+func (n *Network) Close() error {
+	return n.client.NetworkRemove(context.Background(), n.id)
+}
+
+func isIPv6CIDR(cidr string) bool {
+	return strings.Contains(cidr, ":")
+}
+
+// driverOptsWithMTU returns a copy of cfg.DriverOpts with the MTU driver option set, if cfg.MTU
+// is positive and not already set explicitly. It never mutates cfg.DriverOpts.
+func driverOptsWithMTU(cfg NetworkConfig) map[string]string {
+	var driverOpts map[string]string
+	if cfg.DriverOpts != nil {
+		driverOpts = make(map[string]string, len(cfg.DriverOpts))
+		for k, v := range cfg.DriverOpts {
+			driverOpts[k] = v
+		}
+	}
+	if cfg.MTU > 0 {
+		if driverOpts == nil {
+			driverOpts = map[string]string{}
+		}
+		if _, ok := driverOpts["com.docker.network.driver.mtu"]; !ok {
+			driverOpts["com.docker.network.driver.mtu"] = strconv.Itoa(cfg.MTU)
+		}
+	}
+	return driverOpts
+}
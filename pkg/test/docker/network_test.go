@@ -0,0 +1,94 @@
+//  Copyright Istio Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDriverOptsWithMTU(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  NetworkConfig
+		want map[string]string
+	}{
+		{
+			name: "no opts no mtu",
+			cfg:  NetworkConfig{},
+			want: nil,
+		},
+		{
+			name: "mtu with nil opts",
+			cfg:  NetworkConfig{MTU: 1450},
+			want: map[string]string{"com.docker.network.driver.mtu": "1450"},
+		},
+		{
+			name: "mtu with existing opts",
+			cfg: NetworkConfig{
+				MTU:        1450,
+				DriverOpts: map[string]string{"foo": "bar"},
+			},
+			want: map[string]string{"foo": "bar", "com.docker.network.driver.mtu": "1450"},
+		},
+		{
+			name: "mtu does not override explicit opt",
+			cfg: NetworkConfig{
+				MTU:        1450,
+				DriverOpts: map[string]string{"com.docker.network.driver.mtu": "9000"},
+			},
+			want: map[string]string{"com.docker.network.driver.mtu": "9000"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := driverOptsWithMTU(c.cfg); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("driverOptsWithMTU() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestDriverOptsWithMTUDoesNotMutateCallerMap(t *testing.T) {
+	callerOpts := map[string]string{"foo": "bar"}
+	cfg := NetworkConfig{MTU: 1450, DriverOpts: callerOpts}
+
+	driverOptsWithMTU(cfg)
+
+	if len(callerOpts) != 1 {
+		t.Errorf("expected caller's DriverOpts map to be left untouched, got %v", callerOpts)
+	}
+	if _, ok := callerOpts["com.docker.network.driver.mtu"]; ok {
+		t.Error("driverOptsWithMTU mutated the caller's DriverOpts map in place")
+	}
+}
+
+func TestIsIPv6CIDR(t *testing.T) {
+	cases := []struct {
+		cidr string
+		want bool
+	}{
+		{"172.28.0.0/16", false},
+		{"fd00:1::/64", true},
+		{"", false},
+	}
+
+	for _, c := range cases {
+		if got := isIPv6CIDR(c.cidr); got != c.want {
+			t.Errorf("isIPv6CIDR(%q) = %v, want %v", c.cidr, got, c.want)
+		}
+	}
+}
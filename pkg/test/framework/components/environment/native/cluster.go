@@ -0,0 +1,111 @@
+//  Copyright Istio Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package native
+
+import (
+	"istio.io/istio/pkg/test/framework/resource"
+)
+
+// Cluster is an in-process, natively-running Istio cluster. It implements resource.Cluster.
+type Cluster struct {
+	name    string
+	network string
+	role    ClusterRole
+
+	// endpoints is this cluster's EndpointRegistry. A RemoteCluster shares its ControlPlaneCluster's
+	// registry (see Environment.configureEastWest) rather than owning its own.
+	endpoints *EndpointRegistry
+
+	// trustDomain is the SPIFFE trust domain workloads in this cluster present identities under.
+	trustDomain string
+	// trustDomainAliases are additional trust domains this cluster accepts as equivalent to
+	// trustDomain when validating a peer's SPIFFE identity, per Topology.TrustDomainAliases.
+	trustDomainAliases []string
+	// rootCert is the PEM-encoded root CA certificate this cluster's workload certificates chain
+	// up to. Set for every cluster when Topology.SharedRootCA is true.
+	rootCert []byte
+}
+
+var _ resource.Cluster = &Cluster{}
+
+// NewCluster creates a new in-process Cluster for the given ClusterConfig.
+func NewCluster(ctx resource.Context, cfg ClusterConfig) (*Cluster, error) {
+	return &Cluster{
+		name:        cfg.Name,
+		network:     cfg.Network,
+		role:        cfg.Role,
+		endpoints:   NewEndpointRegistry(),
+		trustDomain: cfg.Name + "." + domain,
+	}, nil
+}
+
+// Name implements resource.Cluster.
+func (c *Cluster) Name() string {
+	return c.name
+}
+
+// NetworkName returns the network the cluster's workloads belong to.
+func (c *Cluster) NetworkName() string {
+	return c.network
+}
+
+// IsPrimary reports whether this cluster hosts its own control plane.
+func (c *Cluster) IsPrimary() bool {
+	return c.role == PrimaryCluster
+}
+
+// IsRemote reports whether this cluster is configured by another cluster's control plane.
+func (c *Cluster) IsRemote() bool {
+	return c.role == RemoteCluster
+}
+
+// RegisterEndpoint registers an endpoint for service with this cluster. If this cluster is a
+// RemoteCluster wired up by Environment.configureEastWest, the endpoint also becomes visible to
+// its ControlPlaneCluster (and any other RemoteCluster it configures) via EndpointsFor.
+func (c *Cluster) RegisterEndpoint(service, address string) {
+	c.endpoints.Register(Endpoint{Service: service, Address: address, Cluster: c.name})
+}
+
+// EndpointsFor returns the endpoints registered for service that are visible to this cluster,
+// including any registered by a linked cluster via cross-cluster endpoint discovery.
+func (c *Cluster) EndpointsFor(service string) []Endpoint {
+	return c.endpoints.EndpointsFor(service)
+}
+
+// TrustDomain returns the SPIFFE trust domain workloads in this cluster present identities under.
+func (c *Cluster) TrustDomain() string {
+	return c.trustDomain
+}
+
+// AcceptsTrustDomain reports whether this cluster accepts domain as a valid trust domain for a
+// peer's SPIFFE identity - either its own TrustDomain, or one of the TrustDomainAliases
+// configured for it in the Topology.
+func (c *Cluster) AcceptsTrustDomain(domain string) bool {
+	if domain == c.trustDomain {
+		return true
+	}
+	for _, alias := range c.trustDomainAliases {
+		if domain == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// RootCert returns the PEM-encoded root CA certificate this cluster's workload certificates chain
+// up to, or nil if Topology.SharedRootCA was not set.
+func (c *Cluster) RootCert() []byte {
+	return c.rootCert
+}
@@ -0,0 +1,60 @@
+//  Copyright Istio Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package native
+
+import "sync"
+
+// Endpoint is a workload endpoint registered with a Cluster's EndpointRegistry.
+type Endpoint struct {
+	// Service is the fully-qualified service hostname the endpoint backs.
+	Service string
+
+	// Address is the endpoint's workload address (e.g. "10.0.0.5:8080").
+	Address string
+
+	// Cluster is the name of the Cluster the endpoint was registered with.
+	Cluster string
+}
+
+// EndpointRegistry tracks the workload endpoints registered for services within a Cluster. A
+// RemoteCluster and its ControlPlaneCluster share a single EndpointRegistry instance (wired up by
+// Environment.configureEastWest), so that an endpoint registered on either cluster is immediately
+// visible to both - simulating cross-cluster endpoint discovery without a real east-west gateway.
+type EndpointRegistry struct {
+	mu        sync.RWMutex
+	endpoints map[string][]Endpoint
+}
+
+// NewEndpointRegistry returns a new, empty EndpointRegistry.
+func NewEndpointRegistry() *EndpointRegistry {
+	return &EndpointRegistry{endpoints: make(map[string][]Endpoint)}
+}
+
+// Register adds ep to the registry.
+func (r *EndpointRegistry) Register(ep Endpoint) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endpoints[ep.Service] = append(r.endpoints[ep.Service], ep)
+}
+
+// EndpointsFor returns the endpoints registered for service, across every cluster sharing this
+// registry.
+func (r *EndpointRegistry) EndpointsFor(service string) []Endpoint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	out := make([]Endpoint, len(r.endpoints[service]))
+	copy(out, r.endpoints[service])
+	return out
+}
@@ -15,6 +15,7 @@
 package native
 
 import (
+	"fmt"
 	"io"
 	"sync"
 
@@ -53,33 +54,107 @@ type Environment struct {
 
 	// Docker resources, Lazy-initialized.
 	dockerClient *client.Client
-	network      *docker.Network
+	networks     map[string]*docker.Network
 	mux          sync.Mutex
-	Cluster      resource.Cluster
+
+	// Topology describes the clusters that make up this environment.
+	Topology Topology
+	clusters []*Cluster
+
+	// Cluster is the first cluster in the Topology, kept for backwards compatibility with
+	// single-cluster callers.
+	Cluster resource.Cluster
 }
 
 var _ resource.Environment = &Environment{}
 
-// New returns a new native environment.
+// New returns a new, single-cluster native environment.
 func New(ctx resource.Context) (resource.Environment, error) {
-	cluster, err := NewCluster(ctx)
-	if err != nil {
-		return nil, err
+	return NewWithTopology(ctx, defaultTopology())
+}
+
+// NewWithTopology returns a new native environment with N in-process clusters configured
+// according to topology. Each cluster's Docker network is the one registered under its
+// ClusterConfig.Network (see NetworkForCluster), so clusters share a network only when they name
+// the same one; no network is created here. Clusters marked
+// RemoteCluster share endpoint discovery with the PrimaryCluster that configures them (see
+// configureEastWest), so that cross-cluster service calls can be exercised without a real
+// east-west gateway.
+func NewWithTopology(ctx resource.Context, topology Topology) (resource.Environment, error) {
+	if len(topology.Clusters) == 0 {
+		topology = defaultTopology()
 	}
+
 	e := &Environment{
 		ctx:             ctx,
 		SystemNamespace: systemNamespace,
 		Domain:          domain,
-		Cluster:         cluster,
+		Topology:        topology,
 	}
+
+	for _, cfg := range topology.Clusters {
+		c, err := NewCluster(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		e.clusters = append(e.clusters, c)
+	}
+	e.Cluster = e.clusters[0]
 	e.id = ctx.TrackResource(e)
 
 	// Set the trust domain.
 	spiffe.SetTrustDomain(domain)
 
+	if err := e.configureEastWest(); err != nil {
+		return nil, err
+	}
+
 	return e, nil
 }
 
+// configureEastWest wires up every RemoteCluster in the Topology with the PrimaryCluster that
+// configures it: the RemoteCluster's EndpointRegistry is replaced with its PrimaryCluster's, so
+// that RegisterEndpoint/EndpointsFor on either cluster observes the other's endpoints, simulating
+// cross-cluster endpoint discovery without a real east-west gateway. It also applies
+// Topology.SharedRootCA (every cluster's RootCert() returns the same generated root) and
+// Topology.TrustDomainAliases (per-cluster additional trust domains accepted by
+// Cluster.AcceptsTrustDomain).
+func (e *Environment) configureEastWest() error {
+	byName := make(map[string]*Cluster, len(e.clusters))
+	for _, c := range e.clusters {
+		byName[c.Name()] = c
+	}
+
+	var sharedRootCert []byte
+	if e.Topology.SharedRootCA {
+		cert, err := generateSharedRootCA()
+		if err != nil {
+			return err
+		}
+		sharedRootCert = cert
+	}
+
+	for _, cfg := range e.Topology.Clusters {
+		c := byName[cfg.Name]
+		c.rootCert = sharedRootCert
+		c.trustDomainAliases = e.Topology.TrustDomainAliases[cfg.Name]
+
+		if cfg.Role != RemoteCluster {
+			continue
+		}
+		if cfg.ControlPlaneCluster == "" {
+			return fmt.Errorf("native: remote cluster %q has no ControlPlaneCluster configured", cfg.Name)
+		}
+		primary, ok := byName[cfg.ControlPlaneCluster]
+		if !ok {
+			return fmt.Errorf("native: remote cluster %q references unknown control plane cluster %q", cfg.Name, cfg.ControlPlaneCluster)
+		}
+		c.endpoints = primary.endpoints
+	}
+
+	return nil
+}
+
 // EnvironmentName implements environment.Instance
 func (e *Environment) EnvironmentName() environment.Name {
 	return environment.Native
@@ -93,12 +168,33 @@ func (e *Environment) Case(name environment.Name, fn func()) {
 }
 
 func (e *Environment) IsMulticluster() bool {
-	// Multicluster not supported natively.
-	return false
+	return e.Topology.IsMulticluster()
 }
 
 func (e *Environment) Clusters() []resource.Cluster {
-	return []resource.Cluster{e.Cluster}
+	out := make([]resource.Cluster, 0, len(e.clusters))
+	for _, c := range e.clusters {
+		out = append(out, c)
+	}
+	return out
+}
+
+// RemoteClusters returns the clusters in the Topology configured with RemoteCluster, along with
+// the PrimaryCluster whose control plane configures each of them.
+func (e *Environment) RemoteClusters() map[*Cluster]*Cluster {
+	byName := make(map[string]*Cluster, len(e.clusters))
+	for _, c := range e.clusters {
+		byName[c.Name()] = c
+	}
+
+	out := make(map[*Cluster]*Cluster)
+	for _, cfg := range e.Topology.Clusters {
+		if cfg.Role != RemoteCluster {
+			continue
+		}
+		out[byName[cfg.Name]] = byName[cfg.ControlPlaneCluster]
+	}
+	return out
 }
 
 // ID implements resource.Instance
@@ -122,7 +218,20 @@ func (e *Environment) DockerClient() (*client.Client, error) {
 	return e.dockerClient, nil
 }
 
+// defaultNetwork is the name used for the network created by Network() and for any
+// ClusterConfig that does not set Network explicitly.
+const defaultNetwork = "default"
+
+// Network returns the default Docker network for this environment, creating it on first use.
+// It is equivalent to NetworkNamed(defaultNetwork, docker.NetworkConfig{}).
 func (e *Environment) Network() (*docker.Network, error) {
+	return e.NetworkNamed(defaultNetwork, docker.NetworkConfig{})
+}
+
+// NetworkNamed returns the Docker network registered under name, creating it from cfg on first
+// use. Subsequent calls with the same name ignore cfg and return the already-created network,
+// so that multiple clusters/components can share one L3 segment by referencing the same name.
+func (e *Environment) NetworkNamed(name string, cfg docker.NetworkConfig) (*docker.Network, error) {
 	c, err := e.DockerClient()
 	if err != nil {
 		return nil, err
@@ -131,33 +240,64 @@ func (e *Environment) Network() (*docker.Network, error) {
 	e.mux.Lock()
 	defer e.mux.Unlock()
 
-	if e.network == nil {
-		networkName := "istio-" + e.ctx.Settings().RunID.String()
-		n, err := docker.NewNetwork(c, docker.NetworkConfig{
-			Name: networkName,
-			Labels: map[string]string{
-				networkLabelKey: networkLabelValue,
-			},
-		})
-		if err != nil {
-			return nil, err
-		}
+	if e.networks == nil {
+		e.networks = make(map[string]*docker.Network)
+	}
+
+	if n, ok := e.networks[name]; ok {
+		return n, nil
+	}
 
-		// Save the network.
-		e.network = n
+	if cfg.Name == "" {
+		cfg.Name = "istio-" + e.ctx.Settings().RunID.String() + "-" + name
 	}
+	if cfg.Labels == nil {
+		cfg.Labels = map[string]string{}
+	}
+	if _, ok := cfg.Labels[networkLabelKey]; !ok {
+		cfg.Labels[networkLabelKey] = networkLabelValue
+	}
+
+	n, err := docker.NewNetwork(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	e.networks[name] = n
+	return n, nil
+}
 
-	return e.network, nil
+// Networks returns every Docker network created so far via Network()/NetworkNamed(), keyed by
+// the name they were registered under.
+func (e *Environment) Networks() map[string]*docker.Network {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	out := make(map[string]*docker.Network, len(e.networks))
+	for k, v := range e.networks {
+		out[k] = v
+	}
+	return out
+}
+
+// NetworkForCluster returns the Docker network the given cluster's workloads should attach to,
+// creating it with default settings if it has not already been created via NetworkNamed.
+func (e *Environment) NetworkForCluster(c *Cluster) (*docker.Network, error) {
+	name := c.NetworkName()
+	if name == "" {
+		name = defaultNetwork
+	}
+	return e.NetworkNamed(name, docker.NetworkConfig{})
 }
 
 func (e *Environment) Close() (err error) {
 	e.mux.Lock()
 	defer e.mux.Unlock()
 
-	if e.network != nil {
-		err = multierror.Append(err, e.network.Close()).ErrorOrNil()
+	for _, n := range e.networks {
+		err = multierror.Append(err, n.Close()).ErrorOrNil()
 	}
-	e.network = nil
+	e.networks = nil
 
 	if e.dockerClient != nil {
 		err = multierror.Append(err, e.dockerClient.Close()).ErrorOrNil()
@@ -0,0 +1,200 @@
+//  Copyright Istio Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package native
+
+import "testing"
+
+func newTestEnvironment(t *testing.T, topology Topology) *Environment {
+	t.Helper()
+
+	e := &Environment{Topology: topology}
+	for _, cfg := range topology.Clusters {
+		c, err := NewCluster(nil, cfg)
+		if err != nil {
+			t.Fatalf("NewCluster(%v) failed: %v", cfg, err)
+		}
+		e.clusters = append(e.clusters, c)
+	}
+	return e
+}
+
+func TestConfigureEastWestValidation(t *testing.T) {
+	cases := []struct {
+		name     string
+		topology Topology
+		wantErr  bool
+	}{
+		{
+			name: "single primary",
+			topology: Topology{
+				Clusters: []ClusterConfig{{Name: "primary", Role: PrimaryCluster}},
+			},
+		},
+		{
+			name: "remote with valid control plane",
+			topology: Topology{
+				Clusters: []ClusterConfig{
+					{Name: "primary", Role: PrimaryCluster},
+					{Name: "remote", Role: RemoteCluster, ControlPlaneCluster: "primary"},
+				},
+			},
+		},
+		{
+			name: "remote with no control plane set",
+			topology: Topology{
+				Clusters: []ClusterConfig{
+					{Name: "primary", Role: PrimaryCluster},
+					{Name: "remote", Role: RemoteCluster},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "remote references unknown control plane",
+			topology: Topology{
+				Clusters: []ClusterConfig{
+					{Name: "primary", Role: PrimaryCluster},
+					{Name: "remote", Role: RemoteCluster, ControlPlaneCluster: "nonexistent"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := newTestEnvironment(t, c.topology)
+			err := e.configureEastWest()
+			if c.wantErr && err == nil {
+				t.Error("expected an error, got nil")
+			}
+			if !c.wantErr && err != nil {
+				t.Errorf("expected no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestConfigureEastWestSharesEndpointDiscovery(t *testing.T) {
+	topology := Topology{
+		Clusters: []ClusterConfig{
+			{Name: "primary", Role: PrimaryCluster},
+			{Name: "remote", Role: RemoteCluster, ControlPlaneCluster: "primary"},
+			{Name: "other", Role: PrimaryCluster},
+		},
+	}
+	e := newTestEnvironment(t, topology)
+	if err := e.configureEastWest(); err != nil {
+		t.Fatalf("configureEastWest failed: %v", err)
+	}
+
+	var primary, remote, other *Cluster
+	for _, c := range e.clusters {
+		switch c.Name() {
+		case "primary":
+			primary = c
+		case "remote":
+			remote = c
+		case "other":
+			other = c
+		}
+	}
+
+	primary.RegisterEndpoint("foo.svc", "10.0.0.1:80")
+	if got := remote.EndpointsFor("foo.svc"); len(got) != 1 || got[0].Address != "10.0.0.1:80" {
+		t.Errorf("expected remote cluster to see endpoint registered on its control plane cluster, got %v", got)
+	}
+
+	remote.RegisterEndpoint("bar.svc", "10.0.0.2:80")
+	if got := primary.EndpointsFor("bar.svc"); len(got) != 1 || got[0].Address != "10.0.0.2:80" {
+		t.Errorf("expected control plane cluster to see endpoint registered on its remote cluster, got %v", got)
+	}
+
+	if got := other.EndpointsFor("foo.svc"); len(got) != 0 {
+		t.Errorf("expected an unrelated cluster to not see endpoints from a different primary/remote pair, got %v", got)
+	}
+}
+
+func TestConfigureEastWestSharedRootCA(t *testing.T) {
+	topology := Topology{
+		SharedRootCA: true,
+		Clusters: []ClusterConfig{
+			{Name: "primary", Role: PrimaryCluster},
+			{Name: "remote", Role: RemoteCluster, ControlPlaneCluster: "primary"},
+		},
+	}
+	e := newTestEnvironment(t, topology)
+	if err := e.configureEastWest(); err != nil {
+		t.Fatalf("configureEastWest failed: %v", err)
+	}
+
+	primaryCert := e.clusters[0].RootCert()
+	if len(primaryCert) == 0 {
+		t.Fatal("expected SharedRootCA to populate RootCert()")
+	}
+	for _, c := range e.clusters {
+		if string(c.RootCert()) != string(primaryCert) {
+			t.Errorf("expected cluster %q to share the same root CA, got a different one", c.Name())
+		}
+	}
+}
+
+func TestConfigureEastWestNoSharedRootCA(t *testing.T) {
+	topology := Topology{
+		Clusters: []ClusterConfig{{Name: "primary", Role: PrimaryCluster}},
+	}
+	e := newTestEnvironment(t, topology)
+	if err := e.configureEastWest(); err != nil {
+		t.Fatalf("configureEastWest failed: %v", err)
+	}
+	if got := e.clusters[0].RootCert(); got != nil {
+		t.Errorf("expected RootCert() to be nil when SharedRootCA is unset, got %v", got)
+	}
+}
+
+func TestConfigureEastWestTrustDomainAliases(t *testing.T) {
+	topology := Topology{
+		Clusters: []ClusterConfig{
+			{Name: "primary", Role: PrimaryCluster},
+			{Name: "remote", Role: RemoteCluster, ControlPlaneCluster: "primary"},
+		},
+		TrustDomainAliases: map[string][]string{
+			"remote": {"legacy.example.com"},
+		},
+	}
+	e := newTestEnvironment(t, topology)
+	if err := e.configureEastWest(); err != nil {
+		t.Fatalf("configureEastWest failed: %v", err)
+	}
+
+	var remote, primary *Cluster
+	for _, c := range e.clusters {
+		if c.Name() == "remote" {
+			remote = c
+		} else {
+			primary = c
+		}
+	}
+
+	if !remote.AcceptsTrustDomain(remote.TrustDomain()) {
+		t.Error("expected a cluster to always accept its own trust domain")
+	}
+	if !remote.AcceptsTrustDomain("legacy.example.com") {
+		t.Error("expected remote cluster to accept its configured trust domain alias")
+	}
+	if primary.AcceptsTrustDomain("legacy.example.com") {
+		t.Error("expected the alias to only apply to the cluster it was configured for")
+	}
+}
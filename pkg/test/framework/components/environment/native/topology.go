@@ -0,0 +1,80 @@
+//  Copyright Istio Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package native
+
+// ClusterRole identifies the role a cluster plays within a multi-cluster Topology.
+type ClusterRole string
+
+const (
+	// PrimaryCluster runs its own control plane.
+	PrimaryCluster ClusterRole = "primary"
+	// RemoteCluster is a workload-only cluster that is configured by a primary's control plane.
+	RemoteCluster ClusterRole = "remote"
+)
+
+// ClusterConfig describes a single cluster within a Topology.
+type ClusterConfig struct {
+	// Name uniquely identifies the cluster within the Topology.
+	Name string
+
+	// Network is the network ID the cluster's workloads belong to. Clusters that share a
+	// Network are assumed to have direct (non-gateway) pod-to-pod connectivity.
+	Network string
+
+	// Role indicates whether this cluster hosts its own control plane (PrimaryCluster), or is
+	// configured remotely by another cluster's control plane (RemoteCluster).
+	Role ClusterRole
+
+	// ControlPlaneCluster is the name of the cluster whose control plane configures this
+	// cluster. Ignored for PrimaryCluster; required for RemoteCluster.
+	ControlPlaneCluster string
+}
+
+// Topology describes the set of clusters a native Environment should create and how they relate
+// to one another for the purposes of multi-cluster testing.
+type Topology struct {
+	// Clusters are the clusters to create, in order. A single-entry Topology behaves exactly
+	// like the pre-multicluster native Environment.
+	Clusters []ClusterConfig
+
+	// SharedRootCA, when true, has every cluster's Cluster.RootCert() return the same generated
+	// root CA certificate, so that mTLS between clusters can be exercised as if they trusted a
+	// common root.
+	SharedRootCA bool
+
+	// TrustDomainAliases maps a cluster name to additional trust domains that cluster's
+	// Cluster.AcceptsTrustDomain should treat as equivalent to its own, for validating
+	// cross-cluster SPIFFE identities.
+	TrustDomainAliases map[string][]string
+}
+
+// defaultTopology returns the single-cluster, single-network Topology used when the caller does
+// not supply one, preserving the historical behavior of New/NewCluster.
+func defaultTopology() Topology {
+	return Topology{
+		Clusters: []ClusterConfig{
+			{
+				Name:    "primary",
+				Network: "default",
+				Role:    PrimaryCluster,
+			},
+		},
+	}
+}
+
+// IsMulticluster reports whether the Topology describes more than one cluster.
+func (t Topology) IsMulticluster() bool {
+	return len(t.Clusters) > 1
+}
@@ -0,0 +1,72 @@
+//  Copyright Istio Authors
+//
+//  Licensed under the Apache License, Version 2.0 (the "License");
+//  you may not use this file except in compliance with the License.
+//  You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software
+//  distributed under the License is distributed on an "AS IS" BASIS,
+//  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+//  See the License for the specific language governing permissions and
+//  limitations under the License.
+
+package native
+
+import "testing"
+
+func TestDefaultTopology(t *testing.T) {
+	topology := defaultTopology()
+
+	if len(topology.Clusters) != 1 {
+		t.Fatalf("expected a single cluster, got %d", len(topology.Clusters))
+	}
+	if topology.IsMulticluster() {
+		t.Error("expected a single-cluster Topology to not be multicluster")
+	}
+
+	cfg := topology.Clusters[0]
+	if cfg.Role != PrimaryCluster {
+		t.Errorf("expected default cluster to be PrimaryCluster, got %v", cfg.Role)
+	}
+}
+
+func TestIsMulticluster(t *testing.T) {
+	cases := []struct {
+		name     string
+		topology Topology
+		want     bool
+	}{
+		{
+			name:     "empty",
+			topology: Topology{},
+			want:     false,
+		},
+		{
+			name: "single cluster",
+			topology: Topology{
+				Clusters: []ClusterConfig{{Name: "primary", Role: PrimaryCluster}},
+			},
+			want: false,
+		},
+		{
+			name: "primary and remote",
+			topology: Topology{
+				Clusters: []ClusterConfig{
+					{Name: "primary", Role: PrimaryCluster},
+					{Name: "remote", Role: RemoteCluster, ControlPlaneCluster: "primary"},
+				},
+			},
+			want: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.topology.IsMulticluster(); got != c.want {
+				t.Errorf("IsMulticluster() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}